@@ -0,0 +1,16 @@
+package aws
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_cloudformation_stack":              resourceAwsCloudFormationStack(),
+			"aws_cloudformation_stack_set":          resourceAwsCloudFormationStackSet(),
+			"aws_cloudformation_stack_set_instance": resourceAwsCloudFormationStackSetInstance(),
+		},
+	}
+}
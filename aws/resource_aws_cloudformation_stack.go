@@ -1,9 +1,12 @@
 package aws
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -108,10 +111,124 @@ func resourceAwsCloudFormationStack() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"use_previous_template": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"rollback_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"monitoring_time_in_minutes": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"rollback_triggers": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"arn": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"auto_continue_rollback": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"resources_to_skip": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"use_change_sets": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"change_set_name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tf-",
+			},
+			// Populated from the change set created during the most recent
+			// apply (use_change_sets); CloudFormation computes this
+			// server-side, so it is not available during `terraform plan`.
+			"pending_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"logical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"replacement": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"detect_drift_on_refresh": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"stack_drift_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_operation_events": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"drift": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"logical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"physical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stack_resource_drift_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"property_differences": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+const cloudFormationStackDriftDetectionTimeout = 10 * time.Minute
+
 func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cfconn
 
@@ -163,6 +280,9 @@ func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface
 	if v, ok := d.GetOk("iam_role_arn"); ok {
 		input.RoleARN = aws.String(v.(string))
 	}
+	if v, ok := d.GetOk("rollback_configuration"); ok {
+		input.RollbackConfiguration = expandCloudFormationRollbackConfiguration(v.([]interface{}))
+	}
 
 	log.Printf("[DEBUG] Creating CloudFormation Stack: %s", input)
 	resp, err := conn.CreateStack(&input)
@@ -172,6 +292,8 @@ func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface
 
 	d.SetId(*resp.StackId)
 	var lastStatus string
+	seenEvents := make(map[string]bool)
+	var streamedEvents []cfStreamedEvent
 
 	wait := resource.StateChangeConf{
 		Pending: []string{
@@ -211,6 +333,12 @@ func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface
 					d.Id(), resp)
 			}
 
+			newEvents, err := streamNewCloudFormationStackEvents(conn, d.Id(), nil, seenEvents)
+			if err != nil {
+				log.Printf("[ERROR] Failed to describe stack events: %s", err)
+			}
+			streamedEvents = append(streamedEvents, newEvents...)
+
 			status := *resp.Stacks[0].StackStatus
 			lastStatus = status
 			log.Printf("[DEBUG] Current CloudFormation stack status: %q", status)
@@ -224,6 +352,10 @@ func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface
 		return err
 	}
 
+	if err := setCloudFormationLastOperationEvents(d, streamedEvents); err != nil {
+		return err
+	}
+
 	if lastStatus == cloudformation.StackStatusRollbackComplete || lastStatus == cloudformation.StackStatusRollbackFailed {
 		reasons, err := getCloudFormationRollbackReasons(d.Id(), nil, conn)
 		if err != nil {
@@ -251,10 +383,19 @@ func resourceAwsCloudFormationStackCreate(d *schema.ResourceData, meta interface
 
 	log.Printf("[INFO] CloudFormation Stack %q created", d.Id())
 
-	return resourceAwsCloudFormationStackRead(d, meta)
+	return resourceAwsCloudFormationStackDoRead(d, meta, false)
 }
 
 func resourceAwsCloudFormationStackRead(d *schema.ResourceData, meta interface{}) error {
+	return resourceAwsCloudFormationStackDoRead(d, meta, d.Get("detect_drift_on_refresh").(bool))
+}
+
+// resourceAwsCloudFormationStackDoRead implements the Read logic shared by
+// the Read CRUD entry point and the post-create/post-update refreshes.
+// detectDrift is forced off for the latter so detect_drift_on_refresh only
+// adds its (potentially multi-minute) polling to a genuine
+// `terraform refresh`/plan, not to every apply.
+func resourceAwsCloudFormationStackDoRead(d *schema.ResourceData, meta interface{}, detectDrift bool) error {
 	conn := meta.(*AWSClient).cfconn
 
 	input := &cloudformation.DescribeStacksInput{
@@ -347,12 +488,96 @@ func resourceAwsCloudFormationStackRead(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if detectDrift {
+		if err := resourceAwsCloudFormationStackDetectDrift(d, conn); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// resourceAwsCloudFormationStackDetectDrift kicks off CloudFormation drift
+// detection for the stack, waits for it to reach a terminal state and
+// surfaces the result on the stack_drift_status and drift attributes.
+func resourceAwsCloudFormationStackDetectDrift(d *schema.ResourceData, conn *cloudformation.CloudFormation) error {
+	detectResp, err := conn.DetectStackDrift(&cloudformation.DetectStackDriftInput{
+		StackName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("error detecting CloudFormation stack drift: %s", err)
+	}
+
+	wait := resource.StateChangeConf{
+		Pending: []string{
+			cloudformation.StackDriftDetectionStatusDetectionInProgress,
+		},
+		Target: []string{
+			cloudformation.StackDriftDetectionStatusDetectionComplete,
+			cloudformation.StackDriftDetectionStatusDetectionFailed,
+		},
+		Timeout:    cloudFormationStackDriftDetectionTimeout,
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeStackDriftDetectionStatus(&cloudformation.DescribeStackDriftDetectionStatusInput{
+				StackDriftDetectionId: detectResp.StackDriftDetectionId,
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return out, aws.StringValue(out.DetectionStatus), nil
+		},
+	}
+
+	v, err := wait.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for CloudFormation stack drift detection: %s", err)
+	}
+
+	status := v.(*cloudformation.DescribeStackDriftDetectionStatusOutput)
+	if aws.StringValue(status.DetectionStatus) == cloudformation.StackDriftDetectionStatusDetectionFailed {
+		return fmt.Errorf("CloudFormation stack drift detection failed: %s", aws.StringValue(status.DetectionStatusReason))
+	}
+
+	if err := d.Set("stack_drift_status", status.StackDriftStatus); err != nil {
+		return err
+	}
+
+	var drifts []map[string]interface{}
+	err = conn.DescribeStackResourceDriftsPages(&cloudformation.DescribeStackResourceDriftsInput{
+		StackName: aws.String(d.Id()),
+	}, func(page *cloudformation.DescribeStackResourceDriftsOutput, lastPage bool) bool {
+		for _, rd := range page.StackResourceDrifts {
+			propertyDifferences, err := json.Marshal(rd.PropertyDifferences)
+			if err != nil {
+				log.Printf("[WARN] Unable to marshal CloudFormation drift property differences: %s", err)
+				propertyDifferences = []byte("[]")
+			}
+			drifts = append(drifts, map[string]interface{}{
+				"logical_resource_id":         aws.StringValue(rd.LogicalResourceId),
+				"physical_resource_id":        aws.StringValue(rd.PhysicalResourceId),
+				"stack_resource_drift_status": aws.StringValue(rd.StackResourceDriftStatus),
+				"property_differences":        string(propertyDifferences),
+			})
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return fmt.Errorf("error describing CloudFormation stack resource drifts: %s", err)
+	}
+
+	return d.Set("drift", drifts)
+}
+
 func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cfconn
 
+	if d.Get("auto_continue_rollback").(bool) {
+		if err := resourceAwsCloudFormationStackContinueUpdateRollback(d, conn); err != nil {
+			return err
+		}
+	}
+
 	input := &cloudformation.UpdateStackInput{
 		StackName: aws.String(d.Id()),
 	}
@@ -368,6 +593,9 @@ func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface
 		}
 		input.TemplateBody = aws.String(template)
 	}
+	if input.TemplateURL == nil && input.TemplateBody == nil && d.Get("use_previous_template").(bool) {
+		input.UsePreviousTemplate = aws.Bool(true)
+	}
 
 	// Capabilities must be present whether they are changed or not
 	if v, ok := d.GetOk("capabilities"); ok {
@@ -402,27 +630,44 @@ func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface
 		input.RoleARN = aws.String(d.Get("iam_role_arn").(string))
 	}
 
-	log.Printf("[DEBUG] Updating CloudFormation stack: %s", input)
-	_, err := conn.UpdateStack(input)
-	if err != nil {
-		awsErr, ok := err.(awserr.Error)
-		// ValidationError: No updates are to be performed.
-		if !ok ||
-			awsErr.Code() != "ValidationError" ||
-			awsErr.Message() != "No updates are to be performed." {
-			return err
-		}
-
-		log.Printf("[DEBUG] Current CloudFormation stack has no updates")
+	// RollbackConfiguration must be present whether it is changed or not, since
+	// CloudFormation otherwise resets it to the default on every update.
+	if v, ok := d.GetOk("rollback_configuration"); ok {
+		input.RollbackConfiguration = expandCloudFormationRollbackConfiguration(v.([]interface{}))
 	}
 
+	// Captured before UpdateStack/ExecuteChangeSet is issued so the events
+	// streamed below include the operation's opening events, not just those
+	// after it had already started.
 	lastUpdatedTime, err := getLastCfEventTimestamp(d.Id(), conn)
 	if err != nil {
 		return err
 	}
 
+	log.Printf("[DEBUG] Updating CloudFormation stack: %s", input)
+	if d.Get("use_change_sets").(bool) {
+		if err := resourceAwsCloudFormationStackUpdateViaChangeSet(d, conn, input); err != nil {
+			return err
+		}
+	} else {
+		_, err := conn.UpdateStack(input)
+		if err != nil {
+			awsErr, ok := err.(awserr.Error)
+			// ValidationError: No updates are to be performed.
+			if !ok ||
+				awsErr.Code() != "ValidationError" ||
+				awsErr.Message() != "No updates are to be performed." {
+				return err
+			}
+
+			log.Printf("[DEBUG] Current CloudFormation stack has no updates")
+		}
+	}
+
 	var lastStatus string
 	var stackId string
+	seenEvents := make(map[string]bool)
+	var streamedEvents []cfStreamedEvent
 	wait := resource.StateChangeConf{
 		Pending: []string{
 			cloudformation.StackStatusUpdateCompleteCleanupInProgress,
@@ -449,6 +694,12 @@ func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface
 
 			stackId = aws.StringValue(resp.Stacks[0].StackId)
 
+			newEvents, err := streamNewCloudFormationStackEvents(conn, stackId, lastUpdatedTime, seenEvents)
+			if err != nil {
+				log.Printf("[ERROR] Failed to describe stack events: %s", err)
+			}
+			streamedEvents = append(streamedEvents, newEvents...)
+
 			status := *resp.Stacks[0].StackStatus
 			lastStatus = status
 			log.Printf("[DEBUG] Current CloudFormation stack status: %q", status)
@@ -462,6 +713,10 @@ func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface
 		return err
 	}
 
+	if err := setCloudFormationLastOperationEvents(d, streamedEvents); err != nil {
+		return err
+	}
+
 	if lastStatus == cloudformation.StackStatusUpdateRollbackComplete || lastStatus == cloudformation.StackStatusUpdateRollbackFailed {
 		reasons, err := getCloudFormationRollbackReasons(stackId, lastUpdatedTime, conn)
 		if err != nil {
@@ -473,7 +728,246 @@ func resourceAwsCloudFormationStackUpdate(d *schema.ResourceData, meta interface
 
 	log.Printf("[DEBUG] CloudFormation stack %q has been updated", stackId)
 
-	return resourceAwsCloudFormationStackRead(d, meta)
+	return resourceAwsCloudFormationStackDoRead(d, meta, false)
+}
+
+// resourceAwsCloudFormationStackUpdateViaChangeSet updates a stack through a
+// CloudFormation change set rather than a direct UpdateStack call. The
+// change set is created, described and then executed in the same apply, so
+// pending_changes reflects what the most recent apply's change set
+// contained - it is populated by Update, not by `terraform plan`.
+func resourceAwsCloudFormationStackUpdateViaChangeSet(d *schema.ResourceData, conn *cloudformation.CloudFormation, input *cloudformation.UpdateStackInput) error {
+	preExecuteStatus, err := getCloudFormationStackStatus(aws.StringValue(input.StackName), conn)
+	if err != nil {
+		return err
+	}
+
+	changeSetName := fmt.Sprintf("%s%d", d.Get("change_set_name_prefix").(string), time.Now().Unix())
+
+	// Stack policy fields (StackPolicyBody/StackPolicyURL) are intentionally
+	// not carried over: CreateChangeSetInput has no equivalent, since change
+	// sets don't support updating the stack policy.
+	createInput := &cloudformation.CreateChangeSetInput{
+		StackName:             input.StackName,
+		ChangeSetName:         aws.String(changeSetName),
+		ChangeSetType:         aws.String(cloudformation.ChangeSetTypeUpdate),
+		TemplateBody:          input.TemplateBody,
+		TemplateURL:           input.TemplateURL,
+		UsePreviousTemplate:   input.UsePreviousTemplate,
+		Capabilities:          input.Capabilities,
+		NotificationARNs:      input.NotificationARNs,
+		Parameters:            input.Parameters,
+		Tags:                  input.Tags,
+		RoleARN:               input.RoleARN,
+		RollbackConfiguration: input.RollbackConfiguration,
+	}
+
+	log.Printf("[DEBUG] Creating CloudFormation change set: %s", createInput)
+	resp, err := conn.CreateChangeSet(createInput)
+	if err != nil {
+		return fmt.Errorf("Creating CloudFormation change set failed: %s", err.Error())
+	}
+
+	wait := resource.StateChangeConf{
+		Pending: []string{
+			cloudformation.ChangeSetStatusCreatePending,
+			cloudformation.ChangeSetStatusCreateInProgress,
+		},
+		Target: []string{
+			cloudformation.ChangeSetStatusCreateComplete,
+			cloudformation.ChangeSetStatusFailed,
+		},
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeChangeSet(&cloudformation.DescribeChangeSetInput{
+				ChangeSetName: resp.Id,
+				StackName:     input.StackName,
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return out, aws.StringValue(out.Status), nil
+		},
+	}
+
+	v, err := wait.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	changeSet := v.(*cloudformation.DescribeChangeSetOutput)
+	if aws.StringValue(changeSet.Status) == cloudformation.ChangeSetStatusFailed {
+		reason := aws.StringValue(changeSet.StatusReason)
+		// A change set with no changes fails like "No updates are to be
+		// performed" on UpdateStack - discard it the same way.
+		if strings.Contains(reason, "didn't contain changes") || strings.Contains(reason, "No updates are to be performed") {
+			log.Printf("[DEBUG] CloudFormation change set %q has no changes, deleting it", changeSetName)
+			_, err := conn.DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
+				ChangeSetName: resp.Id,
+				StackName:     input.StackName,
+			})
+			if err != nil {
+				return err
+			}
+			return d.Set("pending_changes", flattenCloudFormationChangeSetChanges(nil))
+		}
+		return fmt.Errorf("CloudFormation change set %q failed: %q", changeSetName, reason)
+	}
+
+	if err := d.Set("pending_changes", flattenCloudFormationChangeSetChanges(changeSet.Changes)); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Executing CloudFormation change set %q", changeSetName)
+	if _, err := conn.ExecuteChangeSet(&cloudformation.ExecuteChangeSetInput{
+		ChangeSetName: resp.Id,
+		StackName:     input.StackName,
+	}); err != nil {
+		return err
+	}
+
+	// ExecuteChangeSet is asynchronous: the stack can still report its
+	// pre-execution terminal status for a window afterward. Wait for it to
+	// leave that status before returning control to the caller's waiter, so
+	// that waiter doesn't observe the stale status and report success before
+	// CloudFormation has applied anything.
+	return waitForCloudFormationStackStatusChange(conn, aws.StringValue(input.StackName), preExecuteStatus, d.Timeout(schema.TimeoutUpdate))
+}
+
+func getCloudFormationStackStatus(stackName string, conn *cloudformation.CloudFormation) (string, error) {
+	resp, err := conn.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error describing CloudFormation stack (%s): %s", stackName, err)
+	}
+
+	return aws.StringValue(resp.Stacks[0].StackStatus), nil
+}
+
+// waitForCloudFormationStackStatusChange waits until the stack's status is
+// no longer staleStatus. It does not wait for any particular terminal
+// status - callers that need one should poll further themselves - it only
+// closes the window during which an asynchronous operation (such as
+// ExecuteChangeSet) has been issued but CloudFormation has not yet moved the
+// stack off its pre-operation status.
+func waitForCloudFormationStackStatusChange(conn *cloudformation.CloudFormation, stackName, staleStatus string, timeout time.Duration) error {
+	wait := resource.StateChangeConf{
+		Pending:    []string{staleStatus},
+		Target:     []string{cloudformation.StackStatusUpdateInProgress},
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			status, err := getCloudFormationStackStatus(stackName, conn)
+			if err != nil {
+				return nil, "", err
+			}
+			// Any status other than the stale one means CloudFormation has
+			// moved on; report it as the target so WaitForState returns.
+			if status != staleStatus {
+				return status, cloudformation.StackStatusUpdateInProgress, nil
+			}
+			return status, status, nil
+		},
+	}
+
+	_, err := wait.WaitForState()
+	return err
+}
+
+func flattenCloudFormationChangeSetChanges(changes []*cloudformation.Change) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(changes))
+	for _, c := range changes {
+		if c.ResourceChange == nil {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"logical_resource_id": aws.StringValue(c.ResourceChange.LogicalResourceId),
+			"action":              aws.StringValue(c.ResourceChange.Action),
+			"replacement":         aws.StringValue(c.ResourceChange.Replacement) == cloudformation.ReplacementTrue,
+		})
+	}
+	return results
+}
+
+func expandCloudFormationRollbackConfiguration(l []interface{}) *cloudformation.RollbackConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &cloudformation.RollbackConfiguration{}
+	if v, ok := m["monitoring_time_in_minutes"].(int); ok && v > 0 {
+		config.MonitoringTimeInMinutes = aws.Int64(int64(v))
+	}
+
+	triggers := m["rollback_triggers"].([]interface{})
+	config.RollbackTriggers = make([]*cloudformation.RollbackTrigger, 0, len(triggers))
+	for _, t := range triggers {
+		tm := t.(map[string]interface{})
+		config.RollbackTriggers = append(config.RollbackTriggers, &cloudformation.RollbackTrigger{
+			Arn:  aws.String(tm["arn"].(string)),
+			Type: aws.String(tm["type"].(string)),
+		})
+	}
+
+	return config
+}
+
+// resourceAwsCloudFormationStackContinueUpdateRollback unblocks a stack stuck
+// in UPDATE_ROLLBACK_FAILED by calling ContinueUpdateRollback before the
+// actual update is attempted, skipping any resources named in
+// resources_to_skip.
+func resourceAwsCloudFormationStackContinueUpdateRollback(d *schema.ResourceData, conn *cloudformation.CloudFormation) error {
+	resp, err := conn.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Stacks) == 0 || aws.StringValue(resp.Stacks[0].StackStatus) != cloudformation.StackStatusUpdateRollbackFailed {
+		return nil
+	}
+
+	input := &cloudformation.ContinueUpdateRollbackInput{
+		StackName: aws.String(d.Id()),
+	}
+	if v, ok := d.GetOk("resources_to_skip"); ok {
+		input.ResourcesToSkip = expandStringList(v.([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Continuing CloudFormation stack update rollback: %s", input)
+	if _, err := conn.ContinueUpdateRollback(input); err != nil {
+		return fmt.Errorf("error continuing CloudFormation stack (%s) update rollback: %s", d.Id(), err)
+	}
+
+	wait := resource.StateChangeConf{
+		Pending: []string{
+			cloudformation.StackStatusUpdateRollbackInProgress,
+			cloudformation.StackStatusUpdateRollbackCompleteCleanupInProgress,
+		},
+		Target: []string{
+			cloudformation.StackStatusUpdateRollbackComplete,
+			cloudformation.StackStatusUpdateRollbackFailed,
+		},
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 5 * time.Second,
+		Refresh:    cfStackStateRefresh(conn, d.Id()),
+	}
+
+	v, err := wait.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for CloudFormation stack (%s) update rollback to continue: %s", d.Id(), err)
+	}
+
+	stack := v.(*cloudformation.Stack)
+	if aws.StringValue(stack.StackStatus) == cloudformation.StackStatusUpdateRollbackFailed {
+		return fmt.Errorf("CloudFormation stack (%s) is still in UPDATE_ROLLBACK_FAILED after ContinueUpdateRollback", d.Id())
+	}
+
+	return nil
 }
 
 func resourceAwsCloudFormationStackDelete(d *schema.ResourceData, meta interface{}) error {
@@ -497,6 +991,8 @@ func resourceAwsCloudFormationStackDelete(d *schema.ResourceData, meta interface
 		return err
 	}
 	var lastStatus string
+	deleteStart := time.Now()
+	seenEvents := make(map[string]bool)
 	wait := resource.StateChangeConf{
 		Pending: []string{
 			cloudformation.StackStatusDeleteInProgress,
@@ -533,6 +1029,10 @@ func resourceAwsCloudFormationStackDelete(d *schema.ResourceData, meta interface
 				return resp, cloudformation.StackStatusDeleteComplete, nil
 			}
 
+			if _, err := streamNewCloudFormationStackEvents(conn, d.Id(), &deleteStart, seenEvents); err != nil {
+				log.Printf("[ERROR] Failed to describe stack events: %s", err)
+			}
+
 			status := *resp.Stacks[0].StackStatus
 			lastStatus = status
 			log.Printf("[DEBUG] Current CloudFormation stack status: %q", status)
@@ -560,6 +1060,74 @@ func resourceAwsCloudFormationStackDelete(d *schema.ResourceData, meta interface
 	return nil
 }
 
+// cfStreamedEvent is the shape recorded in the last_operation_events
+// attribute so that `terraform show` reveals what CloudFormation actually
+// did during the most recent create/update.
+type cfStreamedEvent struct {
+	Timestamp         time.Time `json:"timestamp"`
+	LogicalResourceId string    `json:"logical_resource_id"`
+	ResourceType      string    `json:"resource_type"`
+	ResourceStatus    string    `json:"resource_status"`
+	StatusReason      string    `json:"status_reason,omitempty"`
+}
+
+// streamNewCloudFormationStackEvents pages through the stack's events,
+// logs any not already present in seen at INFO level and returns them so
+// the caller can accumulate a full operation timeline. Events at or before
+// after (when non-nil) are skipped, so a Refresh polling loop only sees
+// events emitted since the operation started.
+func streamNewCloudFormationStackEvents(conn *cloudformation.CloudFormation, stackId string, after *time.Time, seen map[string]bool) ([]cfStreamedEvent, error) {
+	var newEvents []cfStreamedEvent
+
+	err := conn.DescribeStackEventsPages(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stackId),
+	}, func(page *cloudformation.DescribeStackEventsOutput, lastPage bool) bool {
+		for _, e := range page.StackEvents {
+			id := aws.StringValue(e.EventId)
+			if seen[id] {
+				continue
+			}
+			if after != nil && !e.Timestamp.After(*after) {
+				continue
+			}
+			seen[id] = true
+
+			event := cfStreamedEvent{
+				Timestamp:         aws.TimeValue(e.Timestamp),
+				LogicalResourceId: aws.StringValue(e.LogicalResourceId),
+				ResourceType:      aws.StringValue(e.ResourceType),
+				ResourceStatus:    aws.StringValue(e.ResourceStatus),
+				StatusReason:      aws.StringValue(e.ResourceStatusReason),
+			}
+			log.Printf("[INFO] CloudFormation stack %q event: %s %s %s %s",
+				stackId, event.Timestamp.Format(time.RFC3339), event.LogicalResourceId, event.ResourceType, event.ResourceStatus)
+
+			newEvents = append(newEvents, event)
+		}
+		return !lastPage
+	})
+
+	return newEvents, err
+}
+
+// setCloudFormationLastOperationEvents JSON-encodes the accumulated events
+// from the most recent create/update onto the last_operation_events
+// attribute in chronological order. The events arrive as a sequence of
+// per-poll batches, each newest-to-oldest, so a single reversal of the
+// whole slice does not sort it; sort by timestamp instead.
+func setCloudFormationLastOperationEvents(d *schema.ResourceData, events []cfStreamedEvent) error {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	encoded, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("error encoding CloudFormation stack operation events: %s", err)
+	}
+
+	return d.Set("last_operation_events", string(encoded))
+}
+
 // getLastCfEventTimestamp takes the first event in a list
 // of events ordered from the newest to the oldest
 // and extracts timestamp from it
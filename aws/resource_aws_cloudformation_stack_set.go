@@ -0,0 +1,415 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func resourceAwsCloudFormationStackSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCloudFormationStackSetCreate,
+		Read:   resourceAwsCloudFormationStackSetRead,
+		Update: resourceAwsCloudFormationStackSetUpdate,
+		Delete: resourceAwsCloudFormationStackSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"administration_role_arn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"execution_role_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"permission_model": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  cloudformation.PermissionModelsSelfManaged,
+				ValidateFunc: validation.StringInSlice([]string{
+					cloudformation.PermissionModelsSelfManaged,
+					cloudformation.PermissionModelsServiceManaged,
+				}, false),
+			},
+			"auto_deployment": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"retain_stacks_on_account_removal": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"capabilities": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"template_body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateCloudFormationTemplate,
+				StateFunc: func(v interface{}) string {
+					template, _ := normalizeCloudFormationTemplate(v)
+					return template
+				},
+			},
+			"template_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"stack_set_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsCloudFormationStackSetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	if err := validateCloudFormationStackSetAutoDeployment(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	input := &cloudformation.CreateStackSetInput{
+		StackSetName: aws.String(name),
+		ClientRequestToken: aws.String(resource.PrefixedUniqueId(
+			fmt.Sprintf("tf-%s", name))),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("administration_role_arn"); ok {
+		input.AdministrationRoleARN = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("execution_role_name"); ok {
+		input.ExecutionRoleName = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("permission_model"); ok {
+		input.PermissionModel = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("auto_deployment"); ok {
+		input.AutoDeployment = expandCloudFormationStackSetAutoDeployment(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("capabilities"); ok {
+		input.Capabilities = expandStringList(v.(*schema.Set).List())
+	}
+	if v, ok := d.GetOk("template_body"); ok {
+		template, err := normalizeCloudFormationTemplate(v)
+		if err != nil {
+			return fmt.Errorf("template body contains an invalid JSON or YAML: %s", err)
+		}
+		input.TemplateBody = aws.String(template)
+	}
+	if v, ok := d.GetOk("template_url"); ok {
+		input.TemplateURL = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("parameters"); ok {
+		input.Parameters = expandCloudFormationParameters(v.(map[string]interface{}))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		input.Tags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().CloudformationTags()
+	}
+
+	log.Printf("[DEBUG] Creating CloudFormation StackSet: %s", input)
+	_, err := conn.CreateStackSet(input)
+	if err != nil {
+		return fmt.Errorf("error creating CloudFormation StackSet (%s): %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceAwsCloudFormationStackSetRead(d, meta)
+}
+
+func resourceAwsCloudFormationStackSetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	resp, err := conn.DescribeStackSet(&cloudformation.DescribeStackSetInput{
+		StackSetName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudformation.ErrCodeStackSetNotFoundException {
+			log.Printf("[WARN] CloudFormation StackSet (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading CloudFormation StackSet (%s): %s", d.Id(), err)
+	}
+
+	stackSet := resp.StackSet
+	d.Set("name", stackSet.StackSetName)
+	d.Set("description", stackSet.Description)
+	d.Set("administration_role_arn", stackSet.AdministrationRoleARN)
+	d.Set("execution_role_name", stackSet.ExecutionRoleName)
+	d.Set("permission_model", stackSet.PermissionModel)
+	d.Set("template_body", stackSet.TemplateBody)
+	d.Set("arn", stackSet.StackSetARN)
+	d.Set("stack_set_id", stackSet.StackSetId)
+
+	if err := d.Set("auto_deployment", flattenCloudFormationStackSetAutoDeployment(stackSet.AutoDeployment)); err != nil {
+		return fmt.Errorf("error setting auto_deployment: %s", err)
+	}
+
+	if err := d.Set("capabilities", schema.NewSet(schema.HashString, flattenStringList(stackSet.Capabilities))); err != nil {
+		return fmt.Errorf("error setting capabilities: %s", err)
+	}
+
+	originalParams := d.Get("parameters").(map[string]interface{})
+	if err := d.Set("parameters", flattenCloudFormationParameters(stackSet.Parameters, originalParams)); err != nil {
+		return fmt.Errorf("error setting parameters: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.CloudformationKeyValueTags(stackSet.Tags).IgnoreAws().Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsCloudFormationStackSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	if err := validateCloudFormationStackSetAutoDeployment(d); err != nil {
+		return err
+	}
+
+	input := &cloudformation.UpdateStackSetInput{
+		StackSetName: aws.String(d.Id()),
+		OperationId:  aws.String(resource.UniqueId()),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("administration_role_arn"); ok {
+		input.AdministrationRoleARN = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("execution_role_name"); ok {
+		input.ExecutionRoleName = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("permission_model"); ok {
+		input.PermissionModel = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("auto_deployment"); ok {
+		input.AutoDeployment = expandCloudFormationStackSetAutoDeployment(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("capabilities"); ok {
+		input.Capabilities = expandStringList(v.(*schema.Set).List())
+	}
+	if v, ok := d.GetOk("template_url"); ok {
+		input.TemplateURL = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("template_body"); ok && input.TemplateURL == nil {
+		template, err := normalizeCloudFormationTemplate(v)
+		if err != nil {
+			return fmt.Errorf("template body contains an invalid JSON or YAML: %s", err)
+		}
+		input.TemplateBody = aws.String(template)
+	}
+	if v, ok := d.GetOk("parameters"); ok {
+		input.Parameters = expandCloudFormationParameters(v.(map[string]interface{}))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		input.Tags = keyvaluetags.New(v.(map[string]interface{})).IgnoreAws().CloudformationTags()
+	}
+
+	log.Printf("[DEBUG] Updating CloudFormation StackSet: %s", input)
+	_, err := conn.UpdateStackSet(input)
+	if err != nil {
+		return fmt.Errorf("error updating CloudFormation StackSet (%s): %s", d.Id(), err)
+	}
+
+	if err := waitForCloudFormationStackSetOperation(conn, d.Id(), aws.StringValue(input.OperationId), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for CloudFormation StackSet (%s) update: %s", d.Id(), err)
+	}
+
+	return resourceAwsCloudFormationStackSetRead(d, meta)
+}
+
+func resourceAwsCloudFormationStackSetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	log.Printf("[DEBUG] Deleting CloudFormation StackSet: %s", d.Id())
+	_, err := conn.DeleteStackSet(&cloudformation.DeleteStackSetInput{
+		StackSetName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudformation.ErrCodeStackSetNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("error deleting CloudFormation StackSet (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// validateCloudFormationStackSetAutoDeployment rejects auto_deployment for
+// self-managed permission models: CloudFormation only honors it when
+// permission_model is SERVICE_MANAGED, so accepting it silently would leave
+// the configured value with no effect.
+func validateCloudFormationStackSetAutoDeployment(d *schema.ResourceData) error {
+	_, hasAutoDeployment := d.GetOk("auto_deployment")
+	if !hasAutoDeployment {
+		return nil
+	}
+
+	if permissionModel := d.Get("permission_model").(string); permissionModel != cloudformation.PermissionModelsServiceManaged {
+		return fmt.Errorf("auto_deployment is only valid when permission_model is %q, got %q", cloudformation.PermissionModelsServiceManaged, permissionModel)
+	}
+
+	return nil
+}
+
+func expandCloudFormationStackSetAutoDeployment(l []interface{}) *cloudformation.AutoDeployment {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &cloudformation.AutoDeployment{
+		Enabled:                      aws.Bool(m["enabled"].(bool)),
+		RetainStacksOnAccountRemoval: aws.Bool(m["retain_stacks_on_account_removal"].(bool)),
+	}
+}
+
+func flattenCloudFormationStackSetAutoDeployment(ad *cloudformation.AutoDeployment) []map[string]interface{} {
+	if ad == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"enabled":                          aws.BoolValue(ad.Enabled),
+			"retain_stacks_on_account_removal": aws.BoolValue(ad.RetainStacksOnAccountRemoval),
+		},
+	}
+}
+
+// waitForCloudFormationStackSetOperation polls a StackSet operation to a
+// terminal state, mirroring the event-scanning pattern used for stack
+// create/update/delete failures, but sourced from per-account/per-region
+// operation results instead of stack events.
+func waitForCloudFormationStackSetOperation(conn *cloudformation.CloudFormation, stackSetName, operationID string, timeout time.Duration) error {
+	wait := resource.StateChangeConf{
+		Pending: []string{
+			cloudformation.StackSetOperationStatusRunning,
+			cloudformation.StackSetOperationStatusQueued,
+		},
+		Target: []string{
+			cloudformation.StackSetOperationStatusSucceeded,
+			cloudformation.StackSetOperationStatusFailed,
+			cloudformation.StackSetOperationStatusStopped,
+		},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeStackSetOperation(&cloudformation.DescribeStackSetOperationInput{
+				StackSetName: aws.String(stackSetName),
+				OperationId:  aws.String(operationID),
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return out, aws.StringValue(out.StackSetOperation.Status), nil
+		},
+	}
+
+	v, err := wait.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	status := v.(*cloudformation.DescribeStackSetOperationOutput)
+	statusValue := aws.StringValue(status.StackSetOperation.Status)
+	if statusValue == cloudformation.StackSetOperationStatusSucceeded {
+		return nil
+	}
+
+	// STOPPED (e.g. failure tolerance exceeded) is not success either - some
+	// or all of the target accounts/regions did not get the operation
+	// applied, so treat it the same as FAILED.
+	reasons, err := getCloudFormationStackSetOperationFailures(conn, stackSetName, operationID)
+	if err != nil {
+		return fmt.Errorf("StackSet operation %s did not succeed (%s), and the failure reasons could not be retrieved: %s", operationID, statusValue, err)
+	}
+
+	return fmt.Errorf("StackSet operation %s did not succeed (%s): %q", operationID, statusValue, reasons)
+}
+
+func getCloudFormationStackSetOperationFailures(conn *cloudformation.CloudFormation, stackSetName, operationID string) ([]string, error) {
+	var failures []string
+
+	err := conn.ListStackSetOperationResultsPages(&cloudformation.ListStackSetOperationResultsInput{
+		StackSetName: aws.String(stackSetName),
+		OperationId:  aws.String(operationID),
+	}, func(page *cloudformation.ListStackSetOperationResultsOutput, lastPage bool) bool {
+		for _, s := range page.Summaries {
+			status := aws.StringValue(s.Status)
+			if status != cloudformation.StackSetOperationResultStatusFailed && status != cloudformation.StackSetOperationResultStatusCancelled {
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("%s/%s: %s",
+				aws.StringValue(s.Account), aws.StringValue(s.Region), aws.StringValue(s.StatusReason)))
+		}
+		return !lastPage
+	})
+
+	return failures, err
+}
@@ -0,0 +1,324 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAwsCloudFormationStackSetInstance() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCloudFormationStackSetInstanceCreate,
+		Read:   resourceAwsCloudFormationStackSetInstanceRead,
+		Update: resourceAwsCloudFormationStackSetInstanceUpdate,
+		Delete: resourceAwsCloudFormationStackSetInstanceDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"stack_set_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"deployment_targets"},
+			},
+			"deployment_targets": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"account_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"organizational_unit_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"parameter_overrides": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"operation_preferences": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_order": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"failure_tolerance_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"failure_tolerance_percentage": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"max_concurrent_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"max_concurrent_percentage": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsCloudFormationStackSetInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	stackSetName := d.Get("stack_set_name").(string)
+	accountID := d.Get("account_id").(string)
+	region := d.Get("region").(string)
+
+	input := &cloudformation.CreateStackInstancesInput{
+		StackSetName:         aws.String(stackSetName),
+		Regions:              aws.StringSlice([]string{region}),
+		OperationId:          aws.String(resource.UniqueId()),
+		OperationPreferences: expandCloudFormationStackSetOperationPreferences(d.Get("operation_preferences").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("deployment_targets"); ok {
+		input.DeploymentTargets = expandCloudFormationStackSetDeploymentTargets(v.([]interface{}))
+	} else if accountID != "" {
+		input.Accounts = aws.StringSlice([]string{accountID})
+	}
+	if v, ok := d.GetOk("parameter_overrides"); ok {
+		input.ParameterOverrides = expandCloudFormationParameters(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Creating CloudFormation StackSet Instance: %s", input)
+	_, err := conn.CreateStackInstances(input)
+	if err != nil {
+		return fmt.Errorf("error creating CloudFormation StackSet (%s) Instance: %s", stackSetName, err)
+	}
+
+	d.SetId(resourceAwsCloudFormationStackSetInstanceId(stackSetName, accountID, region))
+
+	if err := waitForCloudFormationStackSetOperation(conn, stackSetName, aws.StringValue(input.OperationId), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for CloudFormation StackSet (%s) Instance creation: %s", stackSetName, err)
+	}
+
+	return resourceAwsCloudFormationStackSetInstanceRead(d, meta)
+}
+
+func resourceAwsCloudFormationStackSetInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	stackSetName, accountID, region, err := resourceAwsCloudFormationStackSetInstanceParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if accountID == "" {
+		// Known limitation: instances deployed via deployment_targets
+		// (organizational units) aren't addressable by account, so there is
+		// no single DescribeStackInstance call to reconcile against. This
+		// falls back to confirming the parent StackSet still exists, which
+		// does not detect drift or deletion of the underlying per-account
+		// stacks. The resulting ID (stack_set_name,,region) is also not
+		// unique if more than one deployment_targets instance targets the
+		// same StackSet and region - only one such instance per region is
+		// supported.
+		if _, err := conn.DescribeStackSet(&cloudformation.DescribeStackSetInput{StackSetName: aws.String(stackSetName)}); err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudformation.ErrCodeStackSetNotFoundException {
+				log.Printf("[WARN] CloudFormation StackSet (%s) not found, removing instance from state", stackSetName)
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("error reading CloudFormation StackSet (%s): %s", stackSetName, err)
+		}
+
+		d.Set("stack_set_name", stackSetName)
+		d.Set("region", region)
+		return nil
+	}
+
+	resp, err := conn.DescribeStackInstance(&cloudformation.DescribeStackInstanceInput{
+		StackSetName:         aws.String(stackSetName),
+		StackInstanceAccount: aws.String(accountID),
+		StackInstanceRegion:  aws.String(region),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok &&
+			(awsErr.Code() == cloudformation.ErrCodeStackSetNotFoundException || awsErr.Code() == cloudformation.ErrCodeStackInstanceNotFoundException) {
+			log.Printf("[WARN] CloudFormation StackSet (%s) Instance (%s/%s) not found, removing from state", stackSetName, accountID, region)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading CloudFormation StackSet (%s) Instance (%s/%s): %s", stackSetName, accountID, region, err)
+	}
+
+	instance := resp.StackInstance
+	d.Set("stack_set_name", stackSetName)
+	d.Set("account_id", instance.Account)
+	d.Set("region", instance.Region)
+
+	originalOverrides := d.Get("parameter_overrides").(map[string]interface{})
+	if err := d.Set("parameter_overrides", flattenCloudFormationParameters(instance.ParameterOverrides, originalOverrides)); err != nil {
+		return fmt.Errorf("error setting parameter_overrides: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsCloudFormationStackSetInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	stackSetName := d.Get("stack_set_name").(string)
+	region := d.Get("region").(string)
+	input := &cloudformation.UpdateStackInstancesInput{
+		StackSetName:         aws.String(stackSetName),
+		Regions:              aws.StringSlice([]string{region}),
+		OperationId:          aws.String(resource.UniqueId()),
+		OperationPreferences: expandCloudFormationStackSetOperationPreferences(d.Get("operation_preferences").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("deployment_targets"); ok {
+		input.DeploymentTargets = expandCloudFormationStackSetDeploymentTargets(v.([]interface{}))
+	} else if accountID := d.Get("account_id").(string); accountID != "" {
+		input.Accounts = aws.StringSlice([]string{accountID})
+	}
+	if d.HasChange("parameter_overrides") {
+		input.ParameterOverrides = expandCloudFormationParameters(d.Get("parameter_overrides").(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Updating CloudFormation StackSet Instance: %s", input)
+	_, err := conn.UpdateStackInstances(input)
+	if err != nil {
+		return fmt.Errorf("error updating CloudFormation StackSet (%s) Instance: %s", stackSetName, err)
+	}
+
+	if err := waitForCloudFormationStackSetOperation(conn, stackSetName, aws.StringValue(input.OperationId), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for CloudFormation StackSet (%s) Instance update: %s", stackSetName, err)
+	}
+
+	return resourceAwsCloudFormationStackSetInstanceRead(d, meta)
+}
+
+func resourceAwsCloudFormationStackSetInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cfconn
+
+	stackSetName := d.Get("stack_set_name").(string)
+	region := d.Get("region").(string)
+	input := &cloudformation.DeleteStackInstancesInput{
+		StackSetName: aws.String(stackSetName),
+		Regions:      aws.StringSlice([]string{region}),
+		RetainStacks: aws.Bool(false),
+		OperationId:  aws.String(resource.UniqueId()),
+	}
+
+	if v, ok := d.GetOk("deployment_targets"); ok {
+		input.DeploymentTargets = expandCloudFormationStackSetDeploymentTargets(v.([]interface{}))
+	} else if accountID := d.Get("account_id").(string); accountID != "" {
+		input.Accounts = aws.StringSlice([]string{accountID})
+	}
+
+	log.Printf("[DEBUG] Deleting CloudFormation StackSet Instance: %s", input)
+	_, err := conn.DeleteStackInstances(input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok &&
+			(awsErr.Code() == cloudformation.ErrCodeStackSetNotFoundException || awsErr.Code() == cloudformation.ErrCodeStackInstanceNotFoundException) {
+			return nil
+		}
+		return fmt.Errorf("error deleting CloudFormation StackSet (%s) Instance: %s", stackSetName, err)
+	}
+
+	if err := waitForCloudFormationStackSetOperation(conn, stackSetName, aws.StringValue(input.OperationId), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for CloudFormation StackSet (%s) Instance deletion: %s", stackSetName, err)
+	}
+
+	return nil
+}
+
+func resourceAwsCloudFormationStackSetInstanceId(stackSetName, accountID, region string) string {
+	return strings.Join([]string{stackSetName, accountID, region}, ",")
+}
+
+func resourceAwsCloudFormationStackSetInstanceParseId(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, ",", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format for ID (%q), expected STACK-SET-NAME,ACCOUNT-ID,REGION", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func expandCloudFormationStackSetDeploymentTargets(l []interface{}) *cloudformation.DeploymentTargets {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &cloudformation.DeploymentTargets{
+		OrganizationalUnitIds: expandStringList(m["organizational_unit_ids"].(*schema.Set).List()),
+	}
+}
+
+func expandCloudFormationStackSetOperationPreferences(l []interface{}) *cloudformation.StackSetOperationPreferences {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	prefs := &cloudformation.StackSetOperationPreferences{}
+
+	if v, ok := m["region_order"].([]interface{}); ok && len(v) > 0 {
+		prefs.RegionOrder = expandStringList(v)
+	}
+	if v, ok := m["failure_tolerance_count"].(int); ok && v > 0 {
+		prefs.FailureToleranceCount = aws.Int64(int64(v))
+	}
+	if v, ok := m["failure_tolerance_percentage"].(int); ok && v > 0 {
+		prefs.FailureTolerancePercentage = aws.Int64(int64(v))
+	}
+	if v, ok := m["max_concurrent_count"].(int); ok && v > 0 {
+		prefs.MaxConcurrentCount = aws.Int64(int64(v))
+	}
+	if v, ok := m["max_concurrent_percentage"].(int); ok && v > 0 {
+		prefs.MaxConcurrentPercentage = aws.Int64(int64(v))
+	}
+
+	return prefs
+}
@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestResourceAwsCloudFormationStackSetInstanceId(t *testing.T) {
+	got := resourceAwsCloudFormationStackSetInstanceId("example", "123456789012", "us-east-1")
+	want := "example,123456789012,us-east-1"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestResourceAwsCloudFormationStackSetInstanceParseId(t *testing.T) {
+	cases := []struct {
+		id                              string
+		stackSetName, accountID, region string
+		wantErr                         bool
+	}{
+		{id: "example,123456789012,us-east-1", stackSetName: "example", accountID: "123456789012", region: "us-east-1"},
+		{id: "example,,us-east-1", stackSetName: "example", accountID: "", region: "us-east-1"},
+		{id: "example", wantErr: true},
+		{id: ",123456789012,us-east-1", wantErr: true},
+		{id: "example,123456789012,", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		stackSetName, accountID, region, err := resourceAwsCloudFormationStackSetInstanceParseId(tc.id)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("id %q: expected error, got none", tc.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("id %q: unexpected error: %s", tc.id, err)
+			continue
+		}
+		if stackSetName != tc.stackSetName || accountID != tc.accountID || region != tc.region {
+			t.Errorf("id %q: got (%q, %q, %q), want (%q, %q, %q)",
+				tc.id, stackSetName, accountID, region, tc.stackSetName, tc.accountID, tc.region)
+		}
+	}
+}
+
+func TestExpandCloudFormationStackSetDeploymentTargets(t *testing.T) {
+	if got := expandCloudFormationStackSetDeploymentTargets(nil); got != nil {
+		t.Errorf("expected nil for empty input, got %v", got)
+	}
+
+	in := []interface{}{
+		map[string]interface{}{
+			"organizational_unit_ids": schema.NewSet(schema.HashString, []interface{}{"ou-1", "ou-2"}),
+		},
+	}
+
+	got := expandCloudFormationStackSetDeploymentTargets(in)
+	if got == nil || len(got.OrganizationalUnitIds) != 2 {
+		t.Fatalf("expected 2 organizational unit IDs, got %v", got)
+	}
+}
+
+func TestExpandCloudFormationStackSetOperationPreferences(t *testing.T) {
+	if got := expandCloudFormationStackSetOperationPreferences(nil); got != nil {
+		t.Errorf("expected nil for empty input, got %v", got)
+	}
+
+	in := []interface{}{
+		map[string]interface{}{
+			"region_order":                 []interface{}{"us-east-1", "us-west-2"},
+			"failure_tolerance_count":      1,
+			"failure_tolerance_percentage": 0,
+			"max_concurrent_count":         0,
+			"max_concurrent_percentage":    50,
+		},
+	}
+
+	got := expandCloudFormationStackSetOperationPreferences(in)
+	if got == nil {
+		t.Fatal("expected non-nil preferences")
+	}
+	if len(got.RegionOrder) != 2 {
+		t.Errorf("expected 2 region order entries, got %d", len(got.RegionOrder))
+	}
+	if aws.Int64Value(got.FailureToleranceCount) != 1 {
+		t.Errorf("expected FailureToleranceCount 1, got %d", aws.Int64Value(got.FailureToleranceCount))
+	}
+	if aws.Int64Value(got.MaxConcurrentPercentage) != 50 {
+		t.Errorf("expected MaxConcurrentPercentage 50, got %d", aws.Int64Value(got.MaxConcurrentPercentage))
+	}
+}
@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestExpandCloudFormationStackSetAutoDeployment(t *testing.T) {
+	if got := expandCloudFormationStackSetAutoDeployment(nil); got != nil {
+		t.Errorf("expected nil for empty input, got %v", got)
+	}
+
+	in := []interface{}{
+		map[string]interface{}{
+			"enabled":                          true,
+			"retain_stacks_on_account_removal": false,
+		},
+	}
+
+	got := expandCloudFormationStackSetAutoDeployment(in)
+	if got == nil {
+		t.Fatal("expected non-nil AutoDeployment")
+	}
+	if !aws.BoolValue(got.Enabled) {
+		t.Error("expected Enabled to be true")
+	}
+	if aws.BoolValue(got.RetainStacksOnAccountRemoval) {
+		t.Error("expected RetainStacksOnAccountRemoval to be false")
+	}
+}
+
+func TestFlattenCloudFormationStackSetAutoDeployment(t *testing.T) {
+	if got := flattenCloudFormationStackSetAutoDeployment(nil); got != nil {
+		t.Errorf("expected nil for nil input, got %v", got)
+	}
+
+	ad := &cloudformation.AutoDeployment{
+		Enabled:                      aws.Bool(true),
+		RetainStacksOnAccountRemoval: aws.Bool(true),
+	}
+
+	got := flattenCloudFormationStackSetAutoDeployment(ad)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(got))
+	}
+	if got[0]["enabled"] != true {
+		t.Errorf("expected enabled true, got %v", got[0]["enabled"])
+	}
+	if got[0]["retain_stacks_on_account_removal"] != true {
+		t.Errorf("expected retain_stacks_on_account_removal true, got %v", got[0]["retain_stacks_on_account_removal"])
+	}
+}
+
+func TestValidateCloudFormationStackSetAutoDeployment(t *testing.T) {
+	res := resourceAwsCloudFormationStackSet()
+
+	cases := []struct {
+		name            string
+		permissionModel string
+		autoDeployment  []interface{}
+		wantErr         bool
+	}{
+		{
+			name:            "no auto_deployment set",
+			permissionModel: cloudformation.PermissionModelsSelfManaged,
+		},
+		{
+			name:            "auto_deployment with SERVICE_MANAGED",
+			permissionModel: cloudformation.PermissionModelsServiceManaged,
+			autoDeployment:  []interface{}{map[string]interface{}{"enabled": true}},
+			wantErr:         false,
+		},
+		{
+			name:            "auto_deployment with SELF_MANAGED",
+			permissionModel: cloudformation.PermissionModelsSelfManaged,
+			autoDeployment:  []interface{}{map[string]interface{}{"enabled": true}},
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"permission_model": tc.permissionModel,
+			"auto_deployment":  tc.autoDeployment,
+		})
+
+		err := validateCloudFormationStackSetAutoDeployment(d)
+		if tc.wantErr && err == nil {
+			t.Errorf("%s: expected error, got none", tc.name)
+		}
+		if !tc.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+		}
+	}
+}
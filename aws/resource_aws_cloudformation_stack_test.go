@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestFlattenCloudFormationChangeSetChanges(t *testing.T) {
+	changes := []*cloudformation.Change{
+		{
+			ResourceChange: &cloudformation.ResourceChange{
+				LogicalResourceId: aws.String("myVpc"),
+				Action:            aws.String(cloudformation.ChangeActionModify),
+				Replacement:       aws.String(cloudformation.ReplacementTrue),
+			},
+		},
+		// A change with no ResourceChange (e.g. a Hook invocation) is skipped.
+		{},
+	}
+
+	got := flattenCloudFormationChangeSetChanges(changes)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 flattened change, got %d", len(got))
+	}
+	if got[0]["logical_resource_id"] != "myVpc" {
+		t.Errorf("expected logical_resource_id myVpc, got %v", got[0]["logical_resource_id"])
+	}
+	if got[0]["replacement"] != true {
+		t.Errorf("expected replacement true, got %v", got[0]["replacement"])
+	}
+}
+
+func TestExpandCloudFormationRollbackConfiguration(t *testing.T) {
+	if got := expandCloudFormationRollbackConfiguration(nil); got != nil {
+		t.Errorf("expected nil for empty input, got %v", got)
+	}
+
+	in := []interface{}{
+		map[string]interface{}{
+			"monitoring_time_in_minutes": 5,
+			"rollback_triggers": []interface{}{
+				map[string]interface{}{
+					"arn":  "arn:aws:cloudwatch:us-east-1:123456789012:alarm:example",
+					"type": "AWS::CloudWatch::Alarm",
+				},
+			},
+		},
+	}
+
+	got := expandCloudFormationRollbackConfiguration(in)
+	if got == nil {
+		t.Fatal("expected non-nil RollbackConfiguration")
+	}
+	if aws.Int64Value(got.MonitoringTimeInMinutes) != 5 {
+		t.Errorf("expected MonitoringTimeInMinutes 5, got %d", aws.Int64Value(got.MonitoringTimeInMinutes))
+	}
+	if len(got.RollbackTriggers) != 1 {
+		t.Fatalf("expected 1 rollback trigger, got %d", len(got.RollbackTriggers))
+	}
+	if aws.StringValue(got.RollbackTriggers[0].Type) != "AWS::CloudWatch::Alarm" {
+		t.Errorf("expected rollback trigger type AWS::CloudWatch::Alarm, got %s", aws.StringValue(got.RollbackTriggers[0].Type))
+	}
+}
+
+func TestSetCloudFormationLastOperationEventsSortsAcrossBatches(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+
+	newEvent := func(offset int) cfStreamedEvent {
+		return cfStreamedEvent{Timestamp: t0.Add(time.Duration(offset) * time.Second), LogicalResourceId: string(rune('A' + offset))}
+	}
+
+	// Mirrors two Refresh polls appended in arrival order: each batch is
+	// newest-to-oldest internally, but the second batch is newer overall
+	// than the first - a single reversal of the whole slice would not sort it.
+	events := []cfStreamedEvent{
+		newEvent(2), newEvent(1), newEvent(0), // batch 1: E3,E2,E1
+		newEvent(4), newEvent(3), // batch 2: E5,E4
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAwsCloudFormationStack().Schema, map[string]interface{}{})
+	if err := setCloudFormationLastOperationEvents(d, events); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []cfStreamedEvent
+	if err := json.Unmarshal([]byte(d.Get("last_operation_events").(string)), &got); err != nil {
+		t.Fatalf("failed to unmarshal last_operation_events: %s", err)
+	}
+
+	want := []string{"A", "B", "C", "D", "E"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i, id := range want {
+		if got[i].LogicalResourceId != id {
+			t.Errorf("event %d: expected logical_resource_id %q, got %q", i, id, got[i].LogicalResourceId)
+		}
+	}
+}